@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"clase02-mongo/internal/dao"
+	"clase02-mongo/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpdateOp identifica, dentro de un BulkUpdate, qué item actualizar y con qué patch.
+type UpdateOp struct {
+	ID    string
+	Patch UpdatePatch
+}
+
+// IndexedError asocia un error de validación o de Mongo con la posición que
+// ocupaba la operación dentro del batch, para que el caller pueda saber
+// exactamente cuál de sus N operaciones falló.
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+func (e IndexedError) Error() string {
+	return fmt.Sprintf("op[%d]: %v", e.Index, e.Err)
+}
+
+// BulkResult resume el resultado de una operación bulk: cuántos documentos se
+// vieron afectados y, si hubo fallos parciales, el detalle por índice.
+type BulkResult struct {
+	Inserted    int64
+	Matched     int64
+	Modified    int64
+	Deleted     int64
+	WriteErrors []IndexedError
+}
+
+// BulkCreate inserta varios items en una sola operación BulkWrite. Cada item
+// se valida como en Create antes de armar el batch; los que no pasan
+// validación se reportan en WriteErrors y no llegan a Mongo, pero no frenan
+// al resto salvo que ordered sea true.
+func (r *MongoItemsRepository) BulkCreate(ctx context.Context, items []domain.Item, ordered bool) (BulkResult, error) {
+	models := make([]mongo.WriteModel, 0, len(items))
+	modelOrigin := make([]int, 0, len(items)) // modelOrigin[j] = índice en items del modelo models[j]
+	var result BulkResult
+
+	for i, item := range items {
+		if item.Name == "" {
+			err := IndexedError{Index: i, Err: fmt.Errorf("%w: name no puede estar vacío", ErrValidation)}
+			result.WriteErrors = append(result.WriteErrors, err)
+			if ordered {
+				break
+			}
+			continue
+		}
+		if item.Price < 0 {
+			err := IndexedError{Index: i, Err: fmt.Errorf("%w: price no puede ser negativo", ErrValidation)}
+			result.WriteErrors = append(result.WriteErrors, err)
+			if ordered {
+				break
+			}
+			continue
+		}
+
+		mongoItem := dao.Item{
+			Name:      item.Name,
+			Price:     item.Price,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		models = append(models, mongo.NewInsertOneModel().SetDocument(mongoItem))
+		modelOrigin = append(modelOrigin, i)
+	}
+
+	if len(models) == 0 {
+		return result, nil
+	}
+
+	res, err := r.col.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(ordered))
+	if res != nil {
+		result.Inserted = res.InsertedCount
+	}
+	if err != nil {
+		werrs, topErr := bulkWriteErrors(err, modelOrigin)
+		result.WriteErrors = append(result.WriteErrors, werrs...)
+		return result, topErr
+	}
+	return result, nil
+}
+
+// BulkUpdate aplica varios UpdateOp en una sola operación BulkWrite, usando el
+// mismo $set parcial y las mismas reglas de validación que Update. IDs
+// inválidos y patches que violan esas reglas se reportan en WriteErrors sin
+// llegar a Mongo.
+func (r *MongoItemsRepository) BulkUpdate(ctx context.Context, ops []UpdateOp, ordered bool) (BulkResult, error) {
+	models := make([]mongo.WriteModel, 0, len(ops))
+	modelOrigin := make([]int, 0, len(ops)) // modelOrigin[j] = índice en ops del modelo models[j]
+	var result BulkResult
+
+	for i, op := range ops {
+		idHEX, err := primitive.ObjectIDFromHex(op.ID)
+		if err != nil {
+			result.WriteErrors = append(result.WriteErrors, IndexedError{Index: i, Err: ErrInvalidID})
+			if ordered {
+				break
+			}
+			continue
+		}
+		if err := op.Patch.validate(); err != nil {
+			result.WriteErrors = append(result.WriteErrors, IndexedError{Index: i, Err: err})
+			if ordered {
+				break
+			}
+			continue
+		}
+
+		model := mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": idHEX}).
+			SetUpdate(bson.M{"$set": op.Patch.toSet()})
+		models = append(models, model)
+		modelOrigin = append(modelOrigin, i)
+	}
+
+	if len(models) == 0 {
+		return result, nil
+	}
+
+	res, err := r.col.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(ordered))
+	if res != nil {
+		result.Matched = res.MatchedCount
+		result.Modified = res.ModifiedCount
+	}
+	if err != nil {
+		werrs, topErr := bulkWriteErrors(err, modelOrigin)
+		result.WriteErrors = append(result.WriteErrors, werrs...)
+		return result, topErr
+	}
+	return result, nil
+}
+
+// BulkDelete elimina varios items por ID en una sola operación BulkWrite. IDs
+// inválidos se reportan en WriteErrors sin llegar a Mongo.
+func (r *MongoItemsRepository) BulkDelete(ctx context.Context, ids []string, ordered bool) (BulkResult, error) {
+	models := make([]mongo.WriteModel, 0, len(ids))
+	modelOrigin := make([]int, 0, len(ids)) // modelOrigin[j] = índice en ids del modelo models[j]
+	var result BulkResult
+
+	for i, id := range ids {
+		idHEX, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			result.WriteErrors = append(result.WriteErrors, IndexedError{Index: i, Err: ErrInvalidID})
+			if ordered {
+				break
+			}
+			continue
+		}
+		models = append(models, mongo.NewDeleteOneModel().SetFilter(bson.M{"_id": idHEX}))
+		modelOrigin = append(modelOrigin, i)
+	}
+
+	if len(models) == 0 {
+		return result, nil
+	}
+
+	res, err := r.col.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(ordered))
+	if res != nil {
+		result.Deleted = res.DeletedCount
+	}
+	if err != nil {
+		werrs, topErr := bulkWriteErrors(err, modelOrigin)
+		result.WriteErrors = append(result.WriteErrors, werrs...)
+		return result, topErr
+	}
+	return result, nil
+}
+
+// bulkWriteErrors convierte los errores por-operación de una mongo.BulkWriteException
+// en IndexedError, remapeando el índice que reporta el driver (posición dentro
+// de models) al índice original del slice que recibió el caller, vía
+// modelOrigin. Si err no es una BulkWriteException (ej. timeout, error de
+// conexión), no es un fallo parcial: se devuelve tal cual como segundo
+// resultado para que el caller lo vea con su propio `if err != nil`.
+func bulkWriteErrors(err error, modelOrigin []int) ([]IndexedError, error) {
+	var bwErr mongo.BulkWriteException
+	if !errors.As(err, &bwErr) {
+		return nil, err
+	}
+	indexed := make([]IndexedError, 0, len(bwErr.WriteErrors))
+	for _, we := range bwErr.WriteErrors {
+		indexed = append(indexed, IndexedError{Index: modelOrigin[we.Index], Err: we.WriteError})
+	}
+	return indexed, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"clase02-mongo/internal/domain"
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestBulkCreate_UnorderedRemapsWriteErrorIndexToOriginalSlice cubre el caso
+// que rompía el reporte de índices: un item inválido (rechazado localmente,
+// nunca llega a Mongo) seguido de un item válido que sí falla en Mongo. El
+// driver reporta el fallo de Mongo con el índice dentro de `models`, que acá
+// es distinto del índice dentro de `items`.
+func TestBulkCreate_UnorderedRemapsWriteErrorIndexToOriginalSlice(t *testing.T) {
+	items := []domain.Item{
+		{Name: "valido-0", Price: 1},
+		{Name: "", Price: 1}, // inválido: nunca genera un WriteModel
+		{Name: "valido-2", Price: 1},
+	}
+
+	col := &mockCollection{
+		bulkWriteFn: func(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+			if len(models) != 2 {
+				t.Fatalf("expected 2 models (items 0 and 2), got %d", len(models))
+			}
+			// El segundo modelo (que en items es el índice 2) falla en Mongo.
+			// El driver lo reporta como índice 1, su posición dentro de models.
+			return &mongo.BulkWriteResult{InsertedCount: 1}, mongo.BulkWriteException{
+				WriteErrors: []mongo.BulkWriteError{
+					{WriteError: mongo.WriteError{Index: 1, Code: 11000, Message: "duplicate key"}},
+				},
+			}
+		},
+	}
+	repo := MongoItemsRepository{col: col}
+
+	result, err := repo.BulkCreate(context.Background(), items, false)
+	if err != nil {
+		t.Fatalf("unexpected top-level error: %v", err)
+	}
+	if len(result.WriteErrors) != 2 {
+		t.Fatalf("expected 2 write errors (validation + mongo), got %d: %+v", len(result.WriteErrors), result.WriteErrors)
+	}
+
+	seen := map[int]bool{}
+	for _, we := range result.WriteErrors {
+		seen[we.Index] = true
+	}
+	if !seen[1] {
+		t.Fatalf("expected a write error for original index 1 (validation failure), got %+v", result.WriteErrors)
+	}
+	if !seen[2] {
+		t.Fatalf("expected the Mongo write error remapped to original index 2, got %+v", result.WriteErrors)
+	}
+}
+
+// TestBulkCreate_NonPartialErrorIsReturnedAsRealError cubre el otro bug: un
+// error de BulkWrite que no es un fallo parcial (mongo.BulkWriteException) no
+// debe quedar escondido en WriteErrors con un error nil de vuelta.
+func TestBulkCreate_NonPartialErrorIsReturnedAsRealError(t *testing.T) {
+	items := []domain.Item{{Name: "valido", Price: 1}}
+	wantErr := errors.New("connection refused")
+
+	col := &mockCollection{
+		bulkWriteFn: func(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+			return nil, wantErr
+		},
+	}
+	repo := MongoItemsRepository{col: col}
+
+	_, err := repo.BulkCreate(context.Background(), items, false)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected BulkCreate to surface the connection error, got %v", err)
+	}
+}
+
+// TestBulkUpdate_RejectsInvalidPatchWithoutCallingMongo cubre la misma regla
+// de validación que Create/Update: un patch con price negativo o name vacío
+// se reporta en WriteErrors y nunca llega a BulkWrite.
+func TestBulkUpdate_RejectsInvalidPatchWithoutCallingMongo(t *testing.T) {
+	negativePrice := -50.0
+	emptyName := ""
+	validID := primitive.NewObjectID().Hex()
+
+	ops := []UpdateOp{
+		{ID: validID, Patch: UpdatePatch{Price: &negativePrice}},
+		{ID: validID, Patch: UpdatePatch{Name: &emptyName}},
+	}
+
+	col := &mockCollection{
+		bulkWriteFn: func(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+			t.Fatalf("BulkWrite should not be called when every op fails validation")
+			return nil, nil
+		},
+	}
+	repo := MongoItemsRepository{col: col}
+
+	result, err := repo.BulkUpdate(context.Background(), ops, false)
+	if err != nil {
+		t.Fatalf("unexpected top-level error: %v", err)
+	}
+	if len(result.WriteErrors) != 2 {
+		t.Fatalf("expected 2 write errors, got %d: %+v", len(result.WriteErrors), result.WriteErrors)
+	}
+	for i, we := range result.WriteErrors {
+		if we.Index != i {
+			t.Fatalf("expected write error %d to report original index %d, got %d", i, i, we.Index)
+		}
+		if !errors.Is(we.Err, ErrValidation) {
+			t.Fatalf("expected ErrValidation, got %v", we.Err)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package repository
+
+import "errors"
+
+// Errores tipados del repository. Los callers (handlers, tests) pueden usar
+// errors.Is contra estos en vez de parsear el texto del error.
+var (
+	// ErrNotFound se devuelve cuando una operación busca un item por ID y
+	// ningún documento matchea.
+	ErrNotFound = errors.New("item not found")
+
+	// ErrInvalidID se devuelve cuando el id recibido no es un ObjectID hex válido.
+	ErrInvalidID = errors.New("invalid id format")
+
+	// ErrValidation se devuelve cuando los datos del item no cumplen las
+	// reglas mínimas (name no vacío, price >= 0, etc).
+	ErrValidation = errors.New("validation error")
+
+	// ErrDuplicateName se devuelve cuando Create/Update violan el índice
+	// único de name (ver EnsureIndexes).
+	ErrDuplicateName = errors.New("item name ya existe")
+)
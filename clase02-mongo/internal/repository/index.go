@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexPolicy configura los índices que EnsureIndexes crea sobre la colección.
+// Todos los campos son opcionales: una IndexPolicy{} sólo crea el índice único
+// de name (sin el resto), que es el mínimo para que ErrDuplicateName tenga sentido.
+type IndexPolicy struct {
+	// UniqueNameCollation, si no es nil, hace que el índice único de name sea
+	// case-insensitive usando esta collation (ej. &options.Collation{Locale: "en", Strength: 2}).
+	UniqueNameCollation *options.Collation
+
+	// WithPriceCreatedAtIndex agrega el compound {price:1, createdAt:-1} usado
+	// para range scans ordenados por fecha dentro de un rango de precio.
+	WithPriceCreatedAtIndex bool
+
+	// ExpiresAtTTL, si es > 0, crea un índice TTL sobre expiresAt que borra el
+	// documento ExpiresAtTTL después de alcanzar esa fecha (items soft-deleted).
+	ExpiresAtTTL time.Duration
+}
+
+// toIndexModels traduce la policy a los mongo.IndexModel que hay que crear.
+func (policy IndexPolicy) toIndexModels() []mongo.IndexModel {
+	models := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true).SetCollation(policy.UniqueNameCollation),
+		},
+	}
+
+	if policy.WithPriceCreatedAtIndex {
+		models = append(models, mongo.IndexModel{
+			Keys: bson.D{{Key: "price", Value: 1}, {Key: "createdAt", Value: -1}},
+		})
+	}
+
+	if policy.ExpiresAtTTL > 0 {
+		models = append(models, mongo.IndexModel{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(policy.ExpiresAtTTL.Seconds())),
+		})
+	}
+
+	return models
+}
+
+// EnsureIndexes crea los índices de la colección según policy. Es idempotente:
+// llamarlo varias veces con la misma policy no falla ni duplica índices.
+func (r *MongoItemsRepository) EnsureIndexes(ctx context.Context, policy IndexPolicy) error {
+	_, err := r.col.CreateIndexes(ctx, policy.toIndexModels())
+	return err
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestEnsureIndexes_TranslatesPolicyToIndexModels cubre la traducción de
+// IndexPolicy a mongo.IndexModel: el índice único de name siempre está, la
+// collation se propaga, el compound price+createdAt es condicional a
+// WithPriceCreatedAtIndex, y el TTL se convierte de time.Duration a segundos.
+func TestEnsureIndexes_TranslatesPolicyToIndexModels(t *testing.T) {
+	var captured []mongo.IndexModel
+	col := &mockCollection{
+		createIndexesFn: func(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+			captured = models
+			return []string{"name_1", "price_1_createdAt_-1", "expiresAt_1"}, nil
+		},
+	}
+	repo := MongoItemsRepository{col: col}
+
+	collation := &options.Collation{Locale: "en", Strength: 2}
+	policy := IndexPolicy{
+		UniqueNameCollation:     collation,
+		WithPriceCreatedAtIndex: true,
+		ExpiresAtTTL:            24 * time.Hour,
+	}
+
+	if err := repo.EnsureIndexes(context.Background(), policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(captured) != 3 {
+		t.Fatalf("expected 3 index models (name, price+createdAt, expiresAt TTL), got %d", len(captured))
+	}
+
+	nameIdx := captured[0]
+	if nameIdx.Options == nil || nameIdx.Options.Unique == nil || !*nameIdx.Options.Unique {
+		t.Fatalf("expected the name index to be unique, got %+v", nameIdx.Options)
+	}
+	if nameIdx.Options.Collation != collation {
+		t.Fatalf("expected the configured collation to be wired onto the name index")
+	}
+
+	ttlIdx := captured[2]
+	if ttlIdx.Options == nil || ttlIdx.Options.ExpireAfterSeconds == nil {
+		t.Fatalf("expected the expiresAt index to set ExpireAfterSeconds")
+	}
+	if *ttlIdx.Options.ExpireAfterSeconds != int32((24 * time.Hour).Seconds()) {
+		t.Fatalf("expected ExpireAfterSeconds to be %d, got %d", int32((24*time.Hour).Seconds()), *ttlIdx.Options.ExpireAfterSeconds)
+	}
+}
+
+// TestEnsureIndexes_SkipsOptionalIndexesWhenNotRequested confirma que una
+// IndexPolicy{} vacía sólo crea el índice único de name.
+func TestEnsureIndexes_SkipsOptionalIndexesWhenNotRequested(t *testing.T) {
+	var captured []mongo.IndexModel
+	col := &mockCollection{
+		createIndexesFn: func(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+			captured = models
+			return []string{"name_1"}, nil
+		},
+	}
+	repo := MongoItemsRepository{col: col}
+
+	if err := repo.EnsureIndexes(context.Background(), IndexPolicy{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(captured) != 1 {
+		t.Fatalf("expected only the unique name index, got %d models: %+v", len(captured), captured)
+	}
+}
@@ -5,38 +5,129 @@ import (
 	"clase02-mongo/internal/domain"
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ListOptions describe cómo filtrar, ordenar, paginar y proyectar un listado de items.
+// Todos los campos son opcionales: un ListOptions{} vacío se comporta como el
+// Find(bson.M{}) original (sin filtro, sin límite).
+type ListOptions struct {
+	NameContains  string     // filtra por substring de name (case-insensitive)
+	MinPrice      *float64   // price >= MinPrice
+	MaxPrice      *float64   // price <= MaxPrice
+	CreatedAfter  *time.Time // createdAt >= CreatedAfter
+	CreatedBefore *time.Time // createdAt <= CreatedBefore
+	Sort          bson.D     // ej: bson.D{{Key: "price", Value: -1}}
+	Skip          int64      // documentos a saltear
+	Limit         int64      // 0 = sin límite
+	Projection    bson.M     // ej: bson.M{"name": 1, "price": 1}
+}
+
+// Page es un resultado paginado genérico: los Items de la página actual, el Total
+// de documentos que matchean el filtro (ignorando Skip/Limit) y el Next skip a
+// usar para pedir la siguiente página (nil si no hay más).
+type Page[T any] struct {
+	Items []T
+	Total int64
+	Next  *int64
+}
+
+// toFilter traduce un ListOptions a un filtro bson.M para Mongo.
+func (o ListOptions) toFilter() bson.M {
+	filter := bson.M{}
+
+	if o.NameContains != "" {
+		filter["name"] = bson.M{"$regex": primitive.Regex{Pattern: regexp.QuoteMeta(o.NameContains), Options: "i"}}
+	}
+
+	price := bson.M{}
+	if o.MinPrice != nil {
+		price["$gte"] = *o.MinPrice
+	}
+	if o.MaxPrice != nil {
+		price["$lte"] = *o.MaxPrice
+	}
+	if len(price) > 0 {
+		filter["price"] = price
+	}
+
+	createdAt := bson.M{}
+	if o.CreatedAfter != nil {
+		createdAt["$gte"] = *o.CreatedAfter
+	}
+	if o.CreatedBefore != nil {
+		createdAt["$lte"] = *o.CreatedBefore
+	}
+	if len(createdAt) > 0 {
+		filter["createdAt"] = createdAt
+	}
+
+	return filter
+}
+
 // MongoItemsRepository implementa ItemsRepository usando MongoDB
 type MongoItemsRepository struct {
-	col *mongo.Collection // Referencia a la colección "items" en MongoDB
+	col    MongoCollection // Referencia a la colección "items" en MongoDB (o un mock en tests)
+	client sessionStarter  // Cliente del que cuelga la sesión usada por WithTx (o un mock en tests)
 }
 
 // NewMongoItemsRepository crea una nueva instancia del repository
 // Recibe una referencia a la base de datos MongoDB
 func NewMongoItemsRepository(db *mongo.Database) MongoItemsRepository {
 	return MongoItemsRepository{
-		col: db.Collection("items"), // Conecta con la colección "items"
+		col:    mongoCollection{db.Collection("items")}, // Conecta con la colección "items"
+		client: db.Client(),
 	}
 }
 
-// List obtiene todos los items de MongoDB
-func (r *MongoItemsRepository) List(ctx context.Context) ([]domain.Item, error) {
+// List obtiene items de MongoDB aplicando filtro, orden, paginación y proyección
+// según opts. Un ListOptions{} vacío preserva el comportamiento anterior (todos
+// los items, sin límite).
+//
+// NOTA: el pedido original también pide "un handler API correspondiente" para
+// exponer esto. Este módulo no tiene (todavía) una capa de handlers/HTTP en
+// ningún lado del árbol — no hay paquete handler, router ni main.go de los
+// que colgar uno sin inventar convenciones de cero. Queda pendiente: el día
+// que exista esa capa, debería ser un endpoint delgado que arme ListOptions
+// desde query params y traduzca Page[domain.Item] a la respuesta.
+func (r *MongoItemsRepository) List(ctx context.Context, opts ListOptions) (Page[domain.Item], error) {
 	// ⏰ Timeout para evitar que la operación se cuelgue
 	// Esto es importante en producción para no bloquear indefinidamente
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	// 🔍 Find() sin filtros retorna todos los documentos de la colección
-	// bson.M{} es un filtro vacío (equivale a {} en MongoDB shell)
-	cur, err := r.col.Find(ctx, bson.M{})
+	filter := opts.toFilter()
+
+	total, err := r.col.CountDocuments(ctx, filter)
 	if err != nil {
-		return nil, err
+		return Page[domain.Item]{}, err
+	}
+
+	findOpts := options.Find()
+	if len(opts.Sort) > 0 {
+		findOpts.SetSort(opts.Sort)
+	}
+	if opts.Skip > 0 {
+		findOpts.SetSkip(opts.Skip)
+	}
+	if opts.Limit > 0 {
+		findOpts.SetLimit(opts.Limit)
+	}
+	if len(opts.Projection) > 0 {
+		findOpts.SetProjection(opts.Projection)
+	}
+
+	// 🔍 Find() con el filtro armado a partir de opts (bson.M{} si no se pidió nada)
+	cur, err := r.col.Find(ctx, filter, findOpts)
+	if err != nil {
+		return Page[domain.Item]{}, err
 	}
 	defer cur.Close(ctx) // ⚠️ IMPORTANTE: Siempre cerrar el cursor para liberar recursos
 
@@ -44,7 +135,7 @@ func (r *MongoItemsRepository) List(ctx context.Context) ([]domain.Item, error)
 	// Usamos el modelo DAO porque maneja ObjectID y tags BSON
 	var daoItems []dao.Item
 	if err := cur.All(ctx, &daoItems); err != nil {
-		return nil, err
+		return Page[domain.Item]{}, err
 	}
 
 	// 🔄 Convertir de DAO a Domain (para la capa de negocio)
@@ -54,17 +145,25 @@ func (r *MongoItemsRepository) List(ctx context.Context) ([]domain.Item, error)
 		domainItems[i] = daoItem.ToDomain() // Función definida en dao/Item.go
 	}
 
-	return domainItems, nil
+	page := Page[domain.Item]{Items: domainItems, Total: total}
+	if opts.Limit > 0 {
+		next := opts.Skip + int64(len(domainItems))
+		if next < total {
+			page.Next = &next
+		}
+	}
+
+	return page, nil
 }
 
 // Create inserta un nuevo item en MongoDB
 // Consigna 1: Validar name y price >= 0, agregar timestamps
 func (r *MongoItemsRepository) Create(ctx context.Context, item domain.Item) (domain.Item, error) {
 	if item.Name == "" {
-		return domain.Item{}, errors.New("name no puede estar vacío")
+		return domain.Item{}, fmt.Errorf("%w: name no puede estar vacío", ErrValidation)
 	}
 	if item.Price < 0 {
-		return domain.Item{}, errors.New("price no puede ser negativo")
+		return domain.Item{}, fmt.Errorf("%w: price no puede ser negativo", ErrValidation)
 	}
 
 	mongoItem := dao.Item{
@@ -75,6 +174,9 @@ func (r *MongoItemsRepository) Create(ctx context.Context, item domain.Item) (do
 	}
 	_, err := r.col.InsertOne(ctx, mongoItem)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.Item{}, ErrDuplicateName
+		}
 		return domain.Item{}, err
 	}
 	return item, nil
@@ -85,50 +187,78 @@ func (r *MongoItemsRepository) Create(ctx context.Context, item domain.Item) (do
 func (r *MongoItemsRepository) GetByID(ctx context.Context, id string) (domain.Item, error) {
 	idHEX, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return domain.Item{}, errors.New("invalid id format")
+		return domain.Item{}, ErrInvalidID
 	}
 	var daoItem dao.Item
 	err = r.col.FindOne(ctx, bson.M{"_id": idHEX}).Decode(&daoItem)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return domain.Item{}, errors.New("item not found")
+			return domain.Item{}, ErrNotFound
 		}
 		return domain.Item{}, err
 	}
 	return daoItem.ToDomain(), nil
 }
 
-// Update actualiza un item existente
-// Consigna 3: Update parcial + actualizar updatedAt
-func (r *MongoItemsRepository) Update(ctx context.Context, id string, item domain.Item) (domain.Item, error) {
-	idHEX, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return domain.Item{}, errors.New("invalid id format")
-	}
+// UpdatePatch describe un update parcial de item. A diferencia de pasar un
+// domain.Item completo, cada campo es un puntero: nil significa "no tocar" y
+// permite distinguir "no vino" de "vino en cero" (ej. Price: 0 es un valor
+// válido, no "sin cambios").
+type UpdatePatch struct {
+	Name  *string
+	Price *float64
+}
 
-	updateFields := bson.M{}
-	if item.Name != "" {
-		updateFields["name"] = item.Name
+// validate aplica a los campos presentes en el patch las mismas reglas que
+// Create exige al crear un item (name no vacío, price >= 0). Un campo ausente
+// (nil) no se valida, porque significa "no tocar".
+func (p UpdatePatch) validate() error {
+	if p.Name != nil && *p.Name == "" {
+		return fmt.Errorf("%w: name no puede estar vacío", ErrValidation)
 	}
-	if item.Price != 0 {
-		updateFields["price"] = item.Price
+	if p.Price != nil && *p.Price < 0 {
+		return fmt.Errorf("%w: price no puede ser negativo", ErrValidation)
 	}
-	updateFields["updatedAt"] = time.Now()
+	return nil
+}
 
-	update := bson.M{"$set": updateFields}
+// toSet arma el $set de Mongo a partir de los campos presentes en el patch.
+func (p UpdatePatch) toSet() bson.M {
+	set := bson.M{}
+	if p.Name != nil {
+		set["name"] = *p.Name
+	}
+	if p.Price != nil {
+		set["price"] = *p.Price
+	}
+	set["updatedAt"] = time.Now()
+	return set
+}
 
-	res, err := r.col.UpdateByID(ctx, idHEX, update)
+// Update actualiza un item existente de forma atómica y devuelve el documento
+// ya actualizado, sin necesidad de un FindOne adicional.
+// Consigna 3: Update parcial + actualizar updatedAt
+func (r *MongoItemsRepository) Update(ctx context.Context, id string, patch UpdatePatch) (domain.Item, error) {
+	idHEX, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return domain.Item{}, err
+		return domain.Item{}, ErrInvalidID
 	}
-	if res.MatchedCount == 0 {
-		return domain.Item{}, errors.New("item not found")
+	if err := patch.validate(); err != nil {
+		return domain.Item{}, err
 	}
 
-	// Opcional: devolver el item actualizado consultando de nuevo
+	update := bson.M{"$set": patch.toSet()}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
 	var daoItem dao.Item
-	err = r.col.FindOne(ctx, bson.M{"_id": idHEX}).Decode(&daoItem)
+	err = r.col.FindOneAndUpdate(ctx, bson.M{"_id": idHEX}, update, opts).Decode(&daoItem)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Item{}, ErrNotFound
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.Item{}, ErrDuplicateName
+		}
 		return domain.Item{}, err
 	}
 	return daoItem.ToDomain(), nil
@@ -139,14 +269,14 @@ func (r *MongoItemsRepository) Update(ctx context.Context, id string, item domai
 func (r *MongoItemsRepository) Delete(ctx context.Context, id string) error {
 	idHEX, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("invalid id format")
+		return ErrInvalidID
 	}
 	res, err := r.col.DeleteOne(ctx, bson.M{"_id": idHEX})
 	if err != nil {
 		return err
 	}
 	if res.DeletedCount == 0 {
-		return errors.New("item not found")
+		return ErrNotFound
 	}
 	return nil
 }
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"clase02-mongo/internal/dao"
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestUpdate_ZeroPriceIsPersisted cubre el bug de de55407: Price: 0 es un valor
+// válido para el patch y debe llegar al $set, no ser tratado como "sin cambios".
+func TestUpdate_ZeroPriceIsPersisted(t *testing.T) {
+	id := primitive.NewObjectID()
+	var capturedSet bson.M
+
+	col := &mockCollection{
+		findOneAndUpdateFn: func(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+			capturedSet = update.(bson.M)["$set"].(bson.M)
+			doc := dao.Item{Name: "muestra gratis", Price: 0, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+			return mongo.NewSingleResultFromDocument(doc, nil, nil)
+		},
+	}
+	repo := MongoItemsRepository{col: col}
+
+	price := 0.0
+	_, err := repo.Update(context.Background(), id.Hex(), UpdatePatch{Price: &price})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, ok := capturedSet["price"]
+	if !ok {
+		t.Fatalf("expected price to be present in $set, got %#v", capturedSet)
+	}
+	if got != 0.0 {
+		t.Fatalf("expected price 0, got %v", got)
+	}
+}
+
+// TestUpdate_NilPriceLeavesFieldUntouched confirma que no enviar Price (nil)
+// sigue significando "no tocar", a diferencia de Price: 0.
+func TestUpdate_NilPriceLeavesFieldUntouched(t *testing.T) {
+	id := primitive.NewObjectID()
+	var capturedSet bson.M
+
+	col := &mockCollection{
+		findOneAndUpdateFn: func(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+			capturedSet = update.(bson.M)["$set"].(bson.M)
+			doc := dao.Item{Name: "sin cambios de precio", Price: 42, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+			return mongo.NewSingleResultFromDocument(doc, nil, nil)
+		},
+	}
+	repo := MongoItemsRepository{col: col}
+
+	name := "nuevo nombre"
+	_, err := repo.Update(context.Background(), id.Hex(), UpdatePatch{Name: &name})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := capturedSet["price"]; ok {
+		t.Fatalf("expected price to be absent from $set when not patched, got %#v", capturedSet)
+	}
+}
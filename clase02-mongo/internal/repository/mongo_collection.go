@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoCollection es el subconjunto de *mongo.Collection que usa
+// MongoItemsRepository. Depender de esta interfaz en lugar del tipo concreto
+// permite testear el repository con un mock en memoria, sin levantar un
+// MongoDB real.
+type MongoCollection interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
+	BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+
+	// CreateIndexes crea los índices describidos por models. Se declara acá en
+	// vez de exponer Indexes() mongo.IndexView porque IndexView es un struct
+	// del driver sin constructor exportado: no hay forma de mockearlo. Este
+	// método sí se puede stubear directamente en un mock de MongoCollection.
+	CreateIndexes(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error)
+}
+
+// mongoCollection envuelve *mongo.Collection para exponer CreateIndexes como
+// un método de primer nivel (el driver lo cuelga de col.Indexes().CreateMany).
+type mongoCollection struct {
+	*mongo.Collection
+}
+
+func (c mongoCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	return c.Collection.Indexes().CreateMany(ctx, models, opts...)
+}
+
+// Nos aseguramos en tiempo de compilación de que mongoCollection sigue
+// implementando MongoCollection.
+var _ MongoCollection = mongoCollection{}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mockCollection es un MongoCollection de prueba: cada método delega en el
+// func field correspondiente, que el test setea según lo que necesite
+// ejercitar. Dejar un func field en nil y no llamarlo es el caso normal: cada
+// test sólo completa los métodos que su escenario efectivamente usa.
+type mockCollection struct {
+	insertOneFn        func(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	findFn             func(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
+	findOneFn          func(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	findOneAndUpdateFn func(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult
+	deleteOneFn        func(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	countDocumentsFn   func(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
+	bulkWriteFn        func(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	createIndexesFn    func(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error)
+}
+
+func (m *mockCollection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	return m.insertOneFn(ctx, document, opts...)
+}
+
+func (m *mockCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	return m.findFn(ctx, filter, opts...)
+}
+
+func (m *mockCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	return m.findOneFn(ctx, filter, opts...)
+}
+
+func (m *mockCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	return m.findOneAndUpdateFn(ctx, filter, update, opts...)
+}
+
+func (m *mockCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return m.deleteOneFn(ctx, filter, opts...)
+}
+
+func (m *mockCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	return m.countDocumentsFn(ctx, filter, opts...)
+}
+
+func (m *mockCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	return m.bulkWriteFn(ctx, models, opts...)
+}
+
+func (m *mockCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	return m.createIndexesFn(ctx, models, opts...)
+}
+
+var _ MongoCollection = (*mockCollection)(nil)
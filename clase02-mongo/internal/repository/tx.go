@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sessionStarter es el subconjunto de *mongo.Client que usa WithTx. Depender
+// de esta interfaz en vez del tipo concreto permite testear WithTx con una
+// sesión mockeada (mongo.Session ya es una interfaz del driver), sin levantar
+// un cliente de Mongo real.
+type sessionStarter interface {
+	StartSession(opts ...*options.SessionOptions) (mongo.Session, error)
+}
+
+// Nos aseguramos en tiempo de compilación de que *mongo.Client sigue
+// implementando sessionStarter.
+var _ sessionStarter = (*mongo.Client)(nil)
+
+// WithTx ejecuta fn dentro de una transacción de MongoDB. El ctx que recibe fn
+// ya lleva la sesión adentro (es un mongo.SessionContext), así que cualquier
+// llamada a Create/Update/Delete/Bulk* hecha con ese ctx participa de la misma
+// transacción automáticamente: el driver detecta la sesión a partir del ctx
+// (mongo.SessionFromContext) sin que este repository tenga que pasarla a mano.
+//
+// Esto permite, por ejemplo, escribir un item y un registro de auditoría en
+// colecciones distintas y que ambas escrituras se confirmen (o se deshagan)
+// juntas.
+//
+// session.WithTransaction ya reintenta automáticamente ante
+// TransientTransactionError y UnknownTransactionCommitResult siguiendo el
+// patrón recomendado por el driver, así que no hay que reimplementar ese loop acá.
+func (r *MongoItemsRepository) WithTx(ctx context.Context, fn func(txCtx context.Context) error) error {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mockSessionStarter es un sessionStarter de prueba que siempre devuelve la
+// sesión mockeada configurada.
+type mockSessionStarter struct {
+	session mongo.Session
+	err     error
+}
+
+func (m *mockSessionStarter) StartSession(opts ...*options.SessionOptions) (mongo.Session, error) {
+	return m.session, m.err
+}
+
+// mockSession es un mongo.Session de prueba. Sólo WithTransaction y EndSession
+// importan para WithTx; el resto de la interfaz queda con implementaciones
+// vacías porque ningún escenario de estos tests las ejercita.
+type mockSession struct {
+	withTransactionFn func(ctx context.Context, fn func(sessCtx mongo.SessionContext) (interface{}, error)) (interface{}, error)
+	endSessionCalled  bool
+}
+
+func (s *mockSession) StartTransaction(...*options.TransactionOptions) error { return nil }
+func (s *mockSession) AbortTransaction(context.Context) error               { return nil }
+func (s *mockSession) CommitTransaction(context.Context) error              { return nil }
+func (s *mockSession) WithTransaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) (interface{}, error), opts ...*options.TransactionOptions) (interface{}, error) {
+	return s.withTransactionFn(ctx, fn)
+}
+func (s *mockSession) EndSession(context.Context)                       { s.endSessionCalled = true }
+func (s *mockSession) ClusterTime() bson.Raw                             { return nil }
+func (s *mockSession) AdvanceClusterTime(bson.Raw) error                 { return nil }
+func (s *mockSession) OperationTime() *primitive.Timestamp               { return nil }
+func (s *mockSession) AdvanceOperationTime(*primitive.Timestamp) error   { return nil }
+func (s *mockSession) Client() *mongo.Client                            { return nil }
+func (s *mockSession) ID() bson.Raw                                      { return nil }
+
+var _ mongo.Session = (*mockSession)(nil)
+
+// TestWithTx_RunsFnInsideTransactionAndEndsSession cubre el camino feliz: fn
+// se ejecuta con el ctx que viene de la sesión, y la sesión siempre se cierra.
+func TestWithTx_RunsFnInsideTransactionAndEndsSession(t *testing.T) {
+	session := &mockSession{
+		withTransactionFn: func(ctx context.Context, fn func(sessCtx mongo.SessionContext) (interface{}, error)) (interface{}, error) {
+			return fn(mongo.NewSessionContext(ctx, session))
+		},
+	}
+	repo := MongoItemsRepository{client: &mockSessionStarter{session: session}}
+
+	called := false
+	err := repo.WithTx(context.Background(), func(txCtx context.Context) error {
+		called = true
+		if _, ok := txCtx.(mongo.SessionContext); !ok {
+			t.Fatalf("expected txCtx to carry the session")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Fatalf("expected fn to be called")
+	}
+	if !session.endSessionCalled {
+		t.Fatalf("expected EndSession to be called")
+	}
+}
+
+// TestWithTx_PropagatesFnError confirma que un error de fn se propaga como el
+// error de WithTx (y que la sesión igual se cierra).
+func TestWithTx_PropagatesFnError(t *testing.T) {
+	wantErr := errors.New("audit log write failed")
+	session := &mockSession{
+		withTransactionFn: func(ctx context.Context, fn func(sessCtx mongo.SessionContext) (interface{}, error)) (interface{}, error) {
+			return fn(mongo.NewSessionContext(ctx, session))
+		},
+	}
+	repo := MongoItemsRepository{client: &mockSessionStarter{session: session}}
+
+	err := repo.WithTx(context.Background(), func(txCtx context.Context) error {
+		return wantErr
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected WithTx to propagate fn's error, got %v", err)
+	}
+	if !session.endSessionCalled {
+		t.Fatalf("expected EndSession to be called even on error")
+	}
+}
+
+// TestWithTx_PropagatesStartSessionError cubre el caso en que ni siquiera se
+// puede abrir una sesión.
+func TestWithTx_PropagatesStartSessionError(t *testing.T) {
+	wantErr := errors.New("no reachable servers")
+	repo := MongoItemsRepository{client: &mockSessionStarter{err: wantErr}}
+
+	err := repo.WithTx(context.Background(), func(txCtx context.Context) error {
+		t.Fatalf("fn should not run if StartSession failed")
+		return nil
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected WithTx to propagate the StartSession error, got %v", err)
+	}
+}